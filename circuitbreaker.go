@@ -0,0 +1,207 @@
+package goLogger
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A BreakerState is the state of a target's circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed sends flow normally.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen short-circuits sends until CoolDown elapses.
+	BreakerOpen
+	// BreakerHalfOpen allows a single send through to probe whether the target has recovered.
+	BreakerHalfOpen
+)
+
+// String returns the breaker state's name.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Stats is a snapshot of one target's send counters and circuit breaker state.
+type Stats struct {
+	SentTotal    int64
+	DroppedTotal int64
+	RetriesTotal int64
+	BreakerState BreakerState
+}
+
+// RetryOptions configures a target's retry, backoff, and circuit breaker behaviour.
+type RetryOptions struct {
+	// MaxRetries is the number of additional attempts after the first failed send.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles on each subsequent retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay.
+	MaxDelay time.Duration
+	// FailureThreshold is the failure rate, in [0,1], within the sliding window that opens the breaker.
+	FailureThreshold float64
+	// WindowSize is the number of recent sends considered for FailureThreshold.
+	WindowSize int
+	// CoolDown is how long the breaker stays open before allowing a probe send.
+	CoolDown time.Duration
+	// Fallback, if set, is called with batches that are short-circuited while the breaker is open.
+	// A nil Fallback drops the batch.
+	Fallback func(ctx context.Context, records []slog.Record) error
+}
+
+// DefaultRetryOptions returns the RetryOptions used when a target registers without explicit ones.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries:       3,
+		BaseDelay:        500 * time.Millisecond,
+		MaxDelay:         30 * time.Second,
+		FailureThreshold: 0.5,
+		WindowSize:       20,
+		CoolDown:         30 * time.Second,
+	}
+}
+
+// A RetryAfterError wraps a send error with a server-suggested retry delay, e.g. parsed from a
+// Retry-After response header, so the dispatcher's backoff can honor it instead of its own schedule.
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+// Error returns the wrapped error's message.
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error.
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// A circuitBreaker tracks a sliding window of send outcomes for one target and short-circuits
+// sends while open.
+type circuitBreaker struct {
+	opts RetryOptions
+
+	mu       sync.Mutex
+	state    BreakerState
+	window   []bool
+	openedAt time.Time
+	stats    Stats
+}
+
+// newCircuitBreaker creates a circuitBreaker, filling in zero-valued opts with DefaultRetryOptions.
+// MaxRetries and Fallback are left as given.
+func newCircuitBreaker(opts RetryOptions) *circuitBreaker {
+	d := DefaultRetryOptions()
+	if opts.WindowSize <= 0 {
+		opts.WindowSize = d.WindowSize
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = d.FailureThreshold
+	}
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = d.CoolDown
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = d.BaseDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = d.MaxDelay
+	}
+	return &circuitBreaker{opts: opts, window: make([]bool, 0, opts.WindowSize)}
+}
+
+// allow reports whether a send should be attempted, moving an open breaker to half-open once
+// CoolDown has elapsed. While half-open, only that one probe send is allowed; further sends are
+// short-circuited until the probe's result is recorded.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerHalfOpen {
+		return false
+	}
+	if b.state == BreakerOpen {
+		if time.Since(b.openedAt) < b.opts.CoolDown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+	}
+	return true
+}
+
+// recordResult records a send outcome and opens the breaker once the sliding window holds
+// WindowSize outcomes and the failure rate in it reaches FailureThreshold.
+func (b *circuitBreaker) recordResult(success bool, count int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if success {
+		b.stats.SentTotal += int64(count)
+	}
+
+	if b.state == BreakerHalfOpen {
+		if success {
+			b.state = BreakerClosed
+			b.window = b.window[:0]
+		} else {
+			b.state = BreakerOpen
+			b.openedAt = time.Now()
+		}
+		return
+	}
+
+	if len(b.window) >= b.opts.WindowSize {
+		b.window = b.window[1:]
+	}
+	b.window = append(b.window, success)
+	if len(b.window) < b.opts.WindowSize {
+		return
+	}
+	failures := 0
+	for _, ok := range b.window {
+		if !ok {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(b.window)) >= b.opts.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// recordRetry increments the retry counter.
+func (b *circuitBreaker) recordRetry() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.RetriesTotal++
+}
+
+// recordDrop increments the dropped counter by count.
+func (b *circuitBreaker) recordDrop(count int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.DroppedTotal += int64(count)
+}
+
+// Stats returns a snapshot of the breaker's counters and current state.
+func (b *circuitBreaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := b.stats
+	stats.BreakerState = b.state
+	return stats
+}
+
+// jitter adds up to 20% random jitter to d.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
@@ -0,0 +1,75 @@
+package goLogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	b := newCircuitBreaker(RetryOptions{WindowSize: 4, FailureThreshold: 0.5, CoolDown: 10 * time.Millisecond})
+
+	for i := 0; i < 4; i++ {
+		if !b.allow() {
+			t.Fatalf("expected sends to be allowed while the window is filling")
+		}
+		b.recordResult(false, 1)
+	}
+	if got := b.Stats().BreakerState; got != BreakerOpen {
+		t.Fatalf("expected breaker to open once the failure rate hits FailureThreshold, got %v", got)
+	}
+	if b.allow() {
+		t.Fatalf("expected the breaker to short-circuit sends while open")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() {
+		t.Fatalf("expected a probe send to be allowed once CoolDown has elapsed")
+	}
+	if got := b.Stats().BreakerState; got != BreakerHalfOpen {
+		t.Fatalf("expected half-open after the cooldown probe, got %v", got)
+	}
+
+	b.recordResult(true, 1)
+	if got := b.Stats().BreakerState; got != BreakerClosed {
+		t.Fatalf("expected the breaker to close after a successful probe, got %v", got)
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := newCircuitBreaker(RetryOptions{WindowSize: 2, FailureThreshold: 0.5, CoolDown: 10 * time.Millisecond})
+
+	b.recordResult(false, 1)
+	b.recordResult(false, 1)
+	if got := b.Stats().BreakerState; got != BreakerOpen {
+		t.Fatalf("expected breaker open, got %v", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() {
+		t.Fatalf("expected a probe send to be allowed once CoolDown has elapsed")
+	}
+	b.recordResult(false, 1)
+	if got := b.Stats().BreakerState; got != BreakerOpen {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %v", got)
+	}
+}
+
+func TestCircuitBreakerStatsCounters(t *testing.T) {
+	b := newCircuitBreaker(RetryOptions{WindowSize: 20, FailureThreshold: 0.5, CoolDown: time.Second})
+
+	b.recordResult(true, 3)
+	b.recordDrop(2)
+	b.recordRetry()
+	b.recordRetry()
+
+	stats := b.Stats()
+	if stats.SentTotal != 3 {
+		t.Fatalf("expected SentTotal 3, got %d", stats.SentTotal)
+	}
+	if stats.DroppedTotal != 2 {
+		t.Fatalf("expected DroppedTotal 2, got %d", stats.DroppedTotal)
+	}
+	if stats.RetriesTotal != 2 {
+		t.Fatalf("expected RetriesTotal 2, got %d", stats.RetriesTotal)
+	}
+}
@@ -0,0 +1,280 @@
+package goLogger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// A QueuePolicy controls what happens when a target's queue is full.
+type QueuePolicy int
+
+const (
+	// PolicyBlock blocks the caller until space is available in the queue.
+	PolicyBlock QueuePolicy = iota
+	// PolicyDropOldest drops the oldest queued record to make room for the new one.
+	PolicyDropOldest
+	// PolicyDropNewest drops the incoming record and keeps the queue as is.
+	PolicyDropNewest
+)
+
+// Options configures the bounded dispatcher that sits in front of every LogTarget.
+type Options struct {
+	// QueueCapacity is the number of records buffered per target before QueuePolicy kicks in.
+	QueueCapacity int
+	// Workers is the number of goroutines draining a target's queue.
+	Workers int
+	// MaxBatch is the maximum number of records sent to a target in a single batch.
+	MaxBatch int
+	// FlushInterval is the maximum time a partial batch waits before being flushed.
+	FlushInterval time.Duration
+	// QueuePolicy controls behaviour when a target's queue is full.
+	QueuePolicy QueuePolicy
+	// Retry configures a target's retry, backoff, and circuit breaker behaviour.
+	Retry RetryOptions
+}
+
+// DefaultOptions returns the Options used when New is called without explicit Options.
+func DefaultOptions() Options {
+	return Options{
+		QueueCapacity: 1000,
+		Workers:       1,
+		MaxBatch:      100,
+		FlushInterval: 2 * time.Second,
+		QueuePolicy:   PolicyBlock,
+		Retry:         DefaultRetryOptions(),
+	}
+}
+
+// A BatchLogTarget is a LogTarget that can consume a batch of records in a single call.
+// Targets that implement BatchLogTarget receive the dispatcher's batches directly;
+// targets that only implement LogTarget have their batches unrolled into individual SendLog calls.
+type BatchLogTarget interface {
+	SendLogs(ctx context.Context, records []slog.Record) error
+}
+
+// A targetDispatcher owns a bounded queue and worker pool for a single Target.
+// It batches queued records and flushes them once MaxBatch is reached or FlushInterval elapses.
+type targetDispatcher struct {
+	target       Target
+	batchTarget  BatchLogTarget
+	opts         Options
+	breaker      *circuitBreaker
+	queue        chan slog.Record
+	errorChannel chan error
+	mu           sync.Mutex
+	draining     bool
+	wg           sync.WaitGroup
+}
+
+// newTargetDispatcher creates a targetDispatcher for target and starts its worker pool.
+func newTargetDispatcher(target Target, opts Options, errorChannel chan error) *targetDispatcher {
+	if opts.QueueCapacity <= 0 {
+		opts.QueueCapacity = DefaultOptions().QueueCapacity
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultOptions().Workers
+	}
+	if opts.MaxBatch <= 0 {
+		opts.MaxBatch = DefaultOptions().MaxBatch
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultOptions().FlushInterval
+	}
+	batchTarget, _ := target.LogTarget.(BatchLogTarget)
+	d := &targetDispatcher{
+		target:       target,
+		batchTarget:  batchTarget,
+		opts:         opts,
+		breaker:      newCircuitBreaker(opts.Retry),
+		queue:        make(chan slog.Record, opts.QueueCapacity),
+		errorChannel: errorChannel,
+	}
+	d.wg.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go d.run()
+	}
+	return d
+}
+
+// accepts reports whether r passes the target's TargetOptions filters.
+func (d *targetDispatcher) accepts(r slog.Record) bool {
+	return d.target.accepts(r)
+}
+
+// enqueue adds r to the queue, applying the configured QueuePolicy if the queue is full.
+// It is a no-op once the dispatcher has started draining for shutdown. The draining check and
+// the send are done under the same lock shutdown uses to close the queue, so a send can never
+// race a close. Any record actually dropped by PolicyDropOldest/PolicyDropNewest is counted in
+// Stats().DroppedTotal alongside breaker-short-circuited drops.
+func (d *targetDispatcher) enqueue(r slog.Record) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return
+	}
+
+	switch d.opts.QueuePolicy {
+	case PolicyDropNewest:
+		select {
+		case d.queue <- r:
+		default:
+			d.breaker.recordDrop(1)
+		}
+	case PolicyDropOldest:
+		select {
+		case d.queue <- r:
+		default:
+			select {
+			case <-d.queue:
+				d.breaker.recordDrop(1)
+			default:
+			}
+			select {
+			case d.queue <- r:
+			default:
+				d.breaker.recordDrop(1)
+			}
+		}
+	default:
+		d.queue <- r
+	}
+}
+
+// run drains the queue, grouping records into batches of at most MaxBatch
+// and flushing whenever a batch is full or FlushInterval elapses.
+func (d *targetDispatcher) run() {
+	defer d.wg.Done()
+	batch := make([]slog.Record, 0, d.opts.MaxBatch)
+	ticker := time.NewTicker(d.opts.FlushInterval)
+	defer ticker.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		d.send(batch)
+		batch = make([]slog.Record, 0, d.opts.MaxBatch)
+	}
+
+	for {
+		select {
+		case r, ok := <-d.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, r)
+			if len(batch) >= d.opts.MaxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send delivers batch to the target through the circuit breaker, retrying failed attempts with
+// exponential backoff and jitter up to Retry.MaxRetries. While the breaker is open, batch is
+// short-circuited to Retry.Fallback (or dropped if Fallback is nil).
+func (d *targetDispatcher) send(batch []slog.Record) {
+	if !d.breaker.allow() {
+		d.breaker.recordDrop(len(batch))
+		if d.opts.Retry.Fallback != nil {
+			if err := d.opts.Retry.Fallback(context.Background(), batch); err != nil {
+				d.reportError(err)
+			}
+		}
+		return
+	}
+
+	err := d.sendWithRetry(batch)
+	d.breaker.recordResult(err == nil, len(batch))
+	if err != nil {
+		d.reportError(err)
+	}
+}
+
+// sendWithRetry attempts deliverOnce up to Retry.MaxRetries additional times, backing off
+// exponentially with jitter between attempts and honoring a RetryAfterError's delay when present.
+func (d *targetDispatcher) sendWithRetry(batch []slog.Record) error {
+	delay := d.opts.Retry.BaseDelay
+	var lastErr error
+	for attempt := 0; attempt <= d.opts.Retry.MaxRetries; attempt++ {
+		err := d.deliverOnce(batch)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if attempt == d.opts.Retry.MaxRetries {
+			break
+		}
+
+		wait := jitter(delay)
+		var rae *RetryAfterError
+		if errors.As(err, &rae) && rae.After > 0 {
+			wait = rae.After
+		}
+		d.breaker.recordRetry()
+		time.Sleep(wait)
+
+		delay *= 2
+		if delay > d.opts.Retry.MaxDelay {
+			delay = d.opts.Retry.MaxDelay
+		}
+	}
+	return lastErr
+}
+
+// deliverOnce makes a single delivery attempt, preferring BatchLogTarget when available.
+func (d *targetDispatcher) deliverOnce(batch []slog.Record) error {
+	ctx := context.Background()
+	if d.batchTarget != nil {
+		return d.batchTarget.SendLogs(ctx, batch)
+	}
+	for _, r := range batch {
+		if err := d.target.SendLog(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns a snapshot of this target's send counters and circuit breaker state.
+func (d *targetDispatcher) Stats() Stats {
+	return d.breaker.Stats()
+}
+
+// reportError forwards err to the shared error channel without blocking forever.
+func (d *targetDispatcher) reportError(err error) {
+	select {
+	case d.errorChannel <- err:
+	default:
+	}
+}
+
+// shutdown stops accepting new records, flushes whatever is queued, and waits
+// for the workers to finish or ctx to be done, whichever happens first.
+// draining is set and the queue is closed under d.mu, the same lock enqueue holds across its
+// draining-check-and-send, so no send can land on the queue after it is closed.
+func (d *targetDispatcher) shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	close(d.queue)
+	d.mu.Unlock()
+
+	waited := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
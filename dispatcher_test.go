@@ -0,0 +1,167 @@
+package goLogger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTarget is a LogTarget that records every delivered record.
+type fakeTarget struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (f *fakeTarget) SendLog(_ context.Context, r slog.Record) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records = append(f.records, r)
+	return nil
+}
+
+func (f *fakeTarget) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.records)
+}
+
+// fakeBatchTarget is a LogTarget that also implements BatchLogTarget, recording how many
+// SendLogs calls it received so tests can tell a batch from a series of single sends.
+type fakeBatchTarget struct {
+	mu         sync.Mutex
+	records    []slog.Record
+	batchCalls int
+}
+
+func (f *fakeBatchTarget) SendLog(ctx context.Context, r slog.Record) error {
+	return f.SendLogs(ctx, []slog.Record{r})
+}
+
+func (f *fakeBatchTarget) SendLogs(_ context.Context, records []slog.Record) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records = append(f.records, records...)
+	f.batchCalls++
+	return nil
+}
+
+func (f *fakeBatchTarget) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.records)
+}
+
+// TestDispatcherEnqueueShutdownRace is a regression test for the enqueue/shutdown race: a
+// goroutine hammering enqueue concurrently with shutdown must never panic on a closed channel.
+func TestDispatcherEnqueueShutdownRace(t *testing.T) {
+	target := &fakeTarget{}
+	d := newTargetDispatcher(
+		Target{LogTarget: target},
+		Options{QueueCapacity: 10, Workers: 2, MaxBatch: 5, FlushInterval: 10 * time.Millisecond, Retry: DefaultRetryOptions()},
+		make(chan error, 10),
+	)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				d.enqueue(slog.Record{})
+			}
+		}
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := d.shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestDispatcherDropNewest(t *testing.T) {
+	d := &targetDispatcher{
+		target:       Target{LogTarget: &fakeTarget{}},
+		opts:         Options{QueuePolicy: PolicyDropNewest},
+		breaker:      newCircuitBreaker(DefaultRetryOptions()),
+		queue:        make(chan slog.Record, 2),
+		errorChannel: make(chan error, 1),
+	}
+
+	d.enqueue(slog.Record{Message: "1"})
+	d.enqueue(slog.Record{Message: "2"})
+	d.enqueue(slog.Record{Message: "3"})
+
+	if len(d.queue) != 2 {
+		t.Fatalf("expected queue to stay at capacity 2, got %d", len(d.queue))
+	}
+	first := <-d.queue
+	second := <-d.queue
+	if first.Message != "1" || second.Message != "2" {
+		t.Fatalf("expected the newest record to be dropped, got %q then %q", first.Message, second.Message)
+	}
+	if got := d.Stats().DroppedTotal; got != 1 {
+		t.Fatalf("expected DroppedTotal to count the dropped record, got %d", got)
+	}
+}
+
+func TestDispatcherDropOldest(t *testing.T) {
+	d := &targetDispatcher{
+		target:       Target{LogTarget: &fakeTarget{}},
+		opts:         Options{QueuePolicy: PolicyDropOldest},
+		breaker:      newCircuitBreaker(DefaultRetryOptions()),
+		queue:        make(chan slog.Record, 2),
+		errorChannel: make(chan error, 1),
+	}
+
+	d.enqueue(slog.Record{Message: "1"})
+	d.enqueue(slog.Record{Message: "2"})
+	d.enqueue(slog.Record{Message: "3"})
+
+	if len(d.queue) != 2 {
+		t.Fatalf("expected queue to stay at capacity 2, got %d", len(d.queue))
+	}
+	first := <-d.queue
+	second := <-d.queue
+	if first.Message != "2" || second.Message != "3" {
+		t.Fatalf("expected the oldest record to be dropped, got %q then %q", first.Message, second.Message)
+	}
+	if got := d.Stats().DroppedTotal; got != 1 {
+		t.Fatalf("expected DroppedTotal to count the evicted record, got %d", got)
+	}
+}
+
+func TestDispatcherBatchesToBatchTarget(t *testing.T) {
+	target := &fakeBatchTarget{}
+	d := newTargetDispatcher(
+		Target{LogTarget: target},
+		Options{QueueCapacity: 100, Workers: 1, MaxBatch: 3, FlushInterval: 20 * time.Millisecond, Retry: DefaultRetryOptions()},
+		make(chan error, 10),
+	)
+
+	for i := 0; i < 10; i++ {
+		d.enqueue(slog.Record{})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := d.shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if got := target.len(); got != 10 {
+		t.Fatalf("expected 10 records delivered, got %d", got)
+	}
+	if target.batchCalls == 0 || target.batchCalls == 10 {
+		t.Fatalf("expected records delivered in fewer batches than records, got %d calls for 10 records", target.batchCalls)
+	}
+}
@@ -0,0 +1,50 @@
+package goLogger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// kindAttrKey is the attribute key used to attach a log's kind to a slog.Record.
+const kindAttrKey = "kind"
+
+// kindContextKey is the context key WithKind stores a record's kind under.
+type kindContextKey struct{}
+
+// WithKind returns a copy of ctx carrying kind, so that log records created with it
+// are tagged with kind and can be routed by a TargetOptions kind allow/deny list.
+// For example, WithKind(ctx, "audit") lets a target subscribe only to audit records.
+func WithKind(ctx context.Context, kind string) context.Context {
+	return context.WithValue(ctx, kindContextKey{}, kind)
+}
+
+// kindFromContext returns the kind stored by WithKind, if any.
+func kindFromContext(ctx context.Context) (string, bool) {
+	kind, ok := ctx.Value(kindContextKey{}).(string)
+	return kind, ok
+}
+
+// kindFromRecord returns the kind attached to r by Handle, if any.
+func kindFromRecord(r slog.Record) (string, bool) {
+	var kind string
+	var found bool
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == kindAttrKey {
+			kind = a.Value.String()
+			found = true
+			return false
+		}
+		return true
+	})
+	return kind, found
+}
+
+// containsKind reports whether kind is present in kinds.
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -4,55 +4,90 @@
 package goLogger
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
 	"io"
 	"log/slog"
-	"net/http"
 	"os"
-	"runtime"
-	"sync"
-	"time"
 )
 
 // A LogTarget is an interface that can be implemented to send logs to a specific target.
 // For example, a LogTarget can be implemented to send logs to New Relic.
+// A LogTarget that can also accept a batch of records in a single call should
+// additionally implement BatchLogTarget.
 type LogTarget interface {
 	SendLog(ctx context.Context, r slog.Record) error
 }
 
+// A Logger wraps a *slog.Logger and adds control over the log target dispatchers
+// started by New, most importantly the ability to flush and stop them via Close.
+type Logger struct {
+	*slog.Logger
+	handler *customHandler
+}
+
 // New creates a new logger that writes logs to the provided writer and sends logs to the provided log targets.
-// If the writer is nil, logs will be written to os.Stdout.
-func New(writer io.Writer, logTargets ...LogTarget) *slog.Logger {
+// If the writer is nil, logs will be written to os.Stdout. format selects between the default JSON
+// handler and NewTerminalHandler's human-readable output.
+// Each target is drained by its own bounded worker pool, configured via opts, and only receives
+// the records its TargetOptions accepts. If opts is nil, DefaultOptions is used. Use NewTarget to
+// pair a LogTarget with TargetOptions; a zero-value TargetOptions rejects Debug records (MinLevel
+// defaults to slog.LevelInfo) but applies no other filtering.
+func New(writer io.Writer, format HandlerFormat, opts *Options, logTargets ...Target) *Logger {
 	if writer == nil {
 		writer = os.Stdout
 	}
-	jsonHandler := slog.NewJSONHandler(writer, &slog.HandlerOptions{AddSource: true})
+	dispatcherOpts := DefaultOptions()
+	if opts != nil {
+		dispatcherOpts = *opts
+	}
+
+	var baseHandler slog.Handler
+	switch format {
+	case FormatTerminal:
+		baseHandler = NewTerminalHandler(writer, &slog.HandlerOptions{AddSource: true})
+	default:
+		baseHandler = slog.NewJSONHandler(writer, &slog.HandlerOptions{AddSource: true})
+	}
 	errorChannel := make(chan error)
-	handler := &customHandler{handler: jsonHandler, logTargets: logTargets, errorChannel: errorChannel}
+
+	dispatchers := make([]*targetDispatcher, len(logTargets))
+	for i, target := range logTargets {
+		dispatchers[i] = newTargetDispatcher(target, dispatcherOpts, errorChannel)
+	}
+
+	handler := &customHandler{handler: baseHandler, dispatchers: dispatchers, errorChannel: errorChannel}
 	logger := slog.New(handler)
-	tLogCounter := newTargetLogCounter()
 	go func() {
 		for err := range errorChannel {
-			if tLogCounter.get() > 5 {
-				continue
-			}
 			if err != nil {
 				logger.Error("Error in custom handler", "error", err)
-				tLogCounter.increment()
 			}
 		}
 	}()
-	return logger
+	return &Logger{Logger: logger, handler: handler}
 }
 
-// A customHandler is a slog.Handler that sends logs to the provided log targets.
+// Close flushes every log target's remaining queued records and stops its workers.
+// It returns once all targets are drained or ctx is done, whichever happens first.
+func (l *Logger) Close(ctx context.Context) error {
+	return l.handler.shutdown(ctx)
+}
+
+// Stats returns a snapshot of each registered target's send counters and circuit breaker
+// state, in the order the targets were passed to New.
+func (l *Logger) Stats() []Stats {
+	return l.handler.stats()
+}
+
+// A customHandler is a slog.Handler that hands log records to the target dispatchers.
 type customHandler struct {
 	handler      slog.Handler
-	logTargets   []LogTarget
+	dispatchers  []*targetDispatcher
 	errorChannel chan error
+	// attrs accumulates the attributes attached via WithAttrs (e.g. logger.With(...)) so they
+	// can be folded into the record handed to dispatchers, which have no handler chain of their
+	// own to apply them.
+	attrs []slog.Attr
 }
 
 // Enabled returns true if the provided log level is enabled.
@@ -60,16 +95,21 @@ func (h *customHandler) Enabled(context context.Context, level slog.Level) bool
 	return h.handler.Enabled(context, level)
 }
 
-// Handle sends the provided log record to the log targets.
+// Handle enqueues the provided log record, with any attrs accumulated via WithAttrs folded in,
+// onto every target's dispatcher whose TargetOptions accept it.
 func (h *customHandler) Handle(ctx context.Context, r slog.Record) error {
-	if h.logTargets != nil {
-		for _, target := range h.logTargets {
-			go func() {
-				err := target.SendLog(ctx, r)
-				if err != nil {
-					h.errorChannel <- err
-				}
-			}()
+	if kind, ok := kindFromContext(ctx); ok {
+		r.AddAttrs(slog.String(kindAttrKey, kind))
+	}
+
+	targetRecord := r
+	if len(h.attrs) > 0 {
+		targetRecord = r.Clone()
+		targetRecord.AddAttrs(h.attrs...)
+	}
+	for _, d := range h.dispatchers {
+		if d.accepts(targetRecord) {
+			d.enqueue(targetRecord.Clone())
 		}
 	}
 	return h.handler.Handle(ctx, r)
@@ -77,10 +117,14 @@ func (h *customHandler) Handle(ctx context.Context, r slog.Record) error {
 
 // WithAttrs returns a new customHandler with the provided attributes.
 func (h *customHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	newAttrs = append(newAttrs, h.attrs...)
+	newAttrs = append(newAttrs, attrs...)
 	return &customHandler{
 		handler:      h.handler.WithAttrs(attrs),
-		logTargets:   h.logTargets,
+		dispatchers:  h.dispatchers,
 		errorChannel: h.errorChannel,
+		attrs:        newAttrs,
 	}
 }
 
@@ -88,8 +132,9 @@ func (h *customHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 func (h *customHandler) WithGroup(name string) slog.Handler {
 	return &customHandler{
 		handler:      h.handler.WithGroup(name),
-		logTargets:   h.logTargets,
+		dispatchers:  h.dispatchers,
 		errorChannel: h.errorChannel,
+		attrs:        h.attrs,
 	}
 }
 
@@ -98,102 +143,22 @@ func (h *customHandler) Handler() slog.Handler {
 	return h.handler
 }
 
-// A newRelicLogger is a LogTarget that sends logs to New Relic.
-type newRelicLogger struct {
-	newRelicEndpoint   string
-	newRelicLicenseKey string
-	client             *http.Client
-}
-
-// NewNewRelicLogger creates a new newRelicLogger.
-func NewNewRelicLogger(newRelicEndpoint, newRelicLicenseKey string) LogTarget {
-	return &newRelicLogger{
-		newRelicEndpoint:   newRelicEndpoint,
-		newRelicLicenseKey: newRelicLicenseKey,
-		client:             &http.Client{},
+// shutdown flushes and stops every target dispatcher, returning the first error encountered.
+func (h *customHandler) shutdown(ctx context.Context) error {
+	var firstErr error
+	for _, d := range h.dispatchers {
+		if err := d.shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+	return firstErr
 }
 
-// SendLog sends the provided log record to New Relic.
-func (l *newRelicLogger) SendLog(ctx context.Context, r slog.Record) error {
-	fs := runtime.CallersFrames([]uintptr{r.PC})
-	f, _ := fs.Next()
-	fields := make(map[string]interface{}, r.NumAttrs())
-	fields["time"] = r.Time
-	fields["message"] = r.Message
-	fields["level"] = r.Level.String()
-	fields["timestamp"] = r.Time.Unix()
-	fields["logtype"] = "application"
-	fields["source"] = map[string]interface{}{
-		"function": f.Function,
-		"line":     f.Line,
+// stats collects a Stats snapshot from every target dispatcher.
+func (h *customHandler) stats() []Stats {
+	stats := make([]Stats, len(h.dispatchers))
+	for i, d := range h.dispatchers {
+		stats[i] = d.Stats()
 	}
-	r.Attrs(func(a slog.Attr) bool {
-		fields[a.Key] = a.Value.Any()
-		return true
-	})
-	req, err := http.NewRequest(http.MethodPost, l.newRelicEndpoint, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Api-Key", l.newRelicLicenseKey)
-	jsonFields, err := json.Marshal(fields)
-	if err != nil {
-		return err
-	}
-	req.Body = io.NopCloser(bytes.NewReader(jsonFields))
-	resp, err := l.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-	return nil
-}
-
-// A targetLogCounter is a counter that keeps track of the number of logs created by errors in the log targets.
-// If for example the log target is not reachable, the counter will increment.
-// If the threshold is reached the logger will stop sending logs to the log targets.
-// This prevents infinite loops of logs being created by errors in the log targets.
-type targetLogCounter struct {
-	counter int
-	mu      sync.RWMutex
-}
-
-// newTargetLogCounter creates a new targetLogCounter.
-func newTargetLogCounter() *targetLogCounter {
-	tlc := &targetLogCounter{0, sync.RWMutex{}}
-	go func() {
-		for {
-			if tlc.get() > 0 {
-				tlc.decrement()
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}()
-	return tlc
-}
-
-// increment increments the counter.
-func (c *targetLogCounter) increment() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.counter++
-}
-
-// decrement decrements the counter.
-func (c *targetLogCounter) decrement() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.counter--
-}
-
-// get returns the counter.
-func (c *targetLogCounter) get() int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return c.counter
+	return stats
 }
@@ -0,0 +1,42 @@
+package goLogger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// TestCustomHandlerPredicateSeesWithAttrs is a regression test: a predicate must be able to
+// match attrs attached via logger.With(...), the idiomatic way to attach persistent context,
+// not just attrs passed directly to the logging call.
+func TestCustomHandlerPredicateSeesWithAttrs(t *testing.T) {
+	target := &fakeTarget{}
+	predicateTarget := NewTarget(target, TargetOptions{
+		Predicate: func(r slog.Record) bool {
+			matched := false
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == "service" && a.Value.String() == "payments" {
+					matched = true
+					return false
+				}
+				return true
+			})
+			return matched
+		},
+	})
+
+	logger := New(io.Discard, FormatJSON, nil, predicateTarget)
+	logger.With("service", "payments").Info("hello")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := logger.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := target.len(); got != 1 {
+		t.Fatalf("expected the predicate to match attrs set via With, got %d delivered records", got)
+	}
+}
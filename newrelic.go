@@ -0,0 +1,117 @@
+package goLogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// A newRelicLogger is a LogTarget that sends logs to New Relic.
+// It implements BatchLogTarget so the dispatcher can upload many records in a single request.
+type newRelicLogger struct {
+	newRelicEndpoint   string
+	newRelicLicenseKey string
+	client             *http.Client
+}
+
+// NewNewRelicLogger creates a new newRelicLogger.
+func NewNewRelicLogger(newRelicEndpoint, newRelicLicenseKey string) LogTarget {
+	return &newRelicLogger{
+		newRelicEndpoint:   newRelicEndpoint,
+		newRelicLicenseKey: newRelicLicenseKey,
+		client:             &http.Client{},
+	}
+}
+
+// SendLog sends the provided log record to New Relic.
+func (l *newRelicLogger) SendLog(ctx context.Context, r slog.Record) error {
+	return l.SendLogs(ctx, []slog.Record{r})
+}
+
+// SendLogs sends the provided batch of log records to New Relic's bulk log API in a single,
+// gzip-compressed request.
+func (l *newRelicLogger) SendLogs(ctx context.Context, records []slog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	logs := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		logs = append(logs, recordToFields(r))
+	}
+
+	body, err := json.Marshal(logs)
+	if err != nil {
+		return err
+	}
+
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	if _, err := gzipWriter.Write(body); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.newRelicEndpoint, &gzipped)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Api-Key", l.newRelicLicenseKey)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		sendErr := fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return &RetryAfterError{Err: sendErr, After: after}
+		}
+		return sendErr
+	}
+	return nil
+}
+
+// retryAfter parses a Retry-After header value expressed as a number of seconds.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// recordToFields converts a slog.Record into the flat field map expected by New Relic's log API.
+func recordToFields(r slog.Record) map[string]interface{} {
+	fs := runtime.CallersFrames([]uintptr{r.PC})
+	f, _ := fs.Next()
+	fields := make(map[string]interface{}, r.NumAttrs())
+	fields["time"] = r.Time
+	fields["message"] = r.Message
+	fields["level"] = r.Level.String()
+	fields["timestamp"] = r.Time.Unix()
+	fields["logtype"] = "application"
+	fields["source"] = map[string]interface{}{
+		"function": f.Function,
+		"line":     f.Line,
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fields[a.Key] = a.Value.Any()
+		return true
+	})
+	return fields
+}
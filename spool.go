@@ -0,0 +1,346 @@
+package goLogger
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// frameHeaderSize is the size, in bytes, of a segment frame's length+checksum header.
+const frameHeaderSize = 8
+
+// SpoolOptions configures a spooled target's on-disk segments.
+type SpoolOptions struct {
+	// MaxSegmentSize rotates the active segment once it reaches this many bytes.
+	MaxSegmentSize int64
+	// MaxAge force-rotates the active segment once it has been open this long, so low-volume
+	// targets still get a chance to retry failed deliveries.
+	MaxAge time.Duration
+	// SweepInterval is how often sealed segments are drained and fully-acked ones removed.
+	SweepInterval time.Duration
+}
+
+// DefaultSpoolOptions returns the SpoolOptions used when NewSpooledTarget is called with a
+// zero-value SpoolOptions.
+func DefaultSpoolOptions() SpoolOptions {
+	return SpoolOptions{
+		MaxSegmentSize: 8 << 20, // 8 MiB
+		MaxAge:         10 * time.Minute,
+		SweepInterval:  30 * time.Second,
+	}
+}
+
+// A spooledTarget persists every record to a rotating on-disk segment before forwarding it to
+// inner, so records survive a crash or an outage of inner until they are successfully delivered.
+type spooledTarget struct {
+	inner      LogTarget
+	innerBatch BatchLogTarget
+	dir        string
+	opts       SpoolOptions
+
+	mu       sync.Mutex
+	file     *os.File
+	path     string
+	size     int64
+	openedAt time.Time
+}
+
+// NewSpooledTarget wraps inner with an on-disk spool rooted at dir. On startup it replays and
+// compacts any segments left behind by a previous process, then starts a background sweeper
+// that periodically retries and removes fully-delivered segments.
+func NewSpooledTarget(inner LogTarget, dir string, opts SpoolOptions) (LogTarget, error) {
+	d := DefaultSpoolOptions()
+	if opts.MaxSegmentSize <= 0 {
+		opts.MaxSegmentSize = d.MaxSegmentSize
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = d.MaxAge
+	}
+	if opts.SweepInterval <= 0 {
+		opts.SweepInterval = d.SweepInterval
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	t := &spooledTarget{inner: inner, dir: dir, opts: opts}
+	if batch, ok := inner.(BatchLogTarget); ok {
+		t.innerBatch = batch
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(existing)
+	for _, path := range existing {
+		t.drainFile(path)
+	}
+
+	t.mu.Lock()
+	err = t.rotateLocked()
+	t.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	go t.sweepLoop()
+	return t, nil
+}
+
+// SendLog durably appends r to the active segment, then forwards it to inner. The record is
+// persisted regardless of whether the forward succeeds; a failed forward is retried once its
+// segment is swept, not by returning an error to the caller, since a dispatcher retrying a
+// SendLog/SendLogs call that reports an error would append a duplicate frame for the same record.
+func (t *spooledTarget) SendLog(ctx context.Context, r slog.Record) error {
+	return t.SendLogs(ctx, []slog.Record{r})
+}
+
+// SendLogs durably appends every record to the active segment, then forwards the whole batch to
+// inner in a single call when it implements BatchLogTarget, so wrapping a batch-capable target in
+// a spool does not turn its bulk uploads back into one request per record. Only persistence
+// errors are returned; a forward failure is left for the sweep to retry rather than reported to
+// the caller, so a dispatcher-level retry never re-persists a batch that is already on disk.
+func (t *spooledTarget) SendLogs(ctx context.Context, records []slog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	payloads := make([][]byte, len(records))
+	for i, r := range records {
+		payload, err := encodeSpoolRecord(r)
+		if err != nil {
+			return err
+		}
+		payloads[i] = payload
+	}
+
+	t.mu.Lock()
+	for _, payload := range payloads {
+		if err := writeFrame(t.file, payload); err != nil {
+			t.mu.Unlock()
+			return err
+		}
+		t.size += int64(len(payload)) + frameHeaderSize
+	}
+	if err := t.file.Sync(); err != nil {
+		t.mu.Unlock()
+		return err
+	}
+	if t.size >= t.opts.MaxSegmentSize {
+		if err := t.rotateLocked(); err != nil {
+			t.mu.Unlock()
+			return err
+		}
+	}
+	t.mu.Unlock()
+
+	t.forward(ctx, records)
+	return nil
+}
+
+// forward delivers records to inner as a single batch when it implements BatchLogTarget,
+// otherwise it falls back to one SendLog call per record.
+func (t *spooledTarget) forward(ctx context.Context, records []slog.Record) error {
+	if t.innerBatch != nil {
+		return t.innerBatch.SendLogs(ctx, records)
+	}
+	var firstErr error
+	for _, r := range records {
+		if err := t.inner.SendLog(ctx, r); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// rotateLocked closes the active segment, if any, and opens a new one. Closed segments are left
+// on disk for drainFile to compact and retry; t.mu must be held by the caller.
+func (t *spooledTarget) rotateLocked() error {
+	if t.file != nil {
+		if err := t.file.Close(); err != nil {
+			return err
+		}
+	}
+	path := filepath.Join(t.dir, fmt.Sprintf("%020d.seg", time.Now().UnixNano()))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	t.file = file
+	t.path = path
+	t.size = 0
+	t.openedAt = time.Now()
+	return nil
+}
+
+// sweepLoop periodically force-rotates a stale active segment and drains sealed segments.
+func (t *spooledTarget) sweepLoop() {
+	ticker := time.NewTicker(t.opts.SweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		t.sweepOnce()
+	}
+}
+
+// sweepOnce force-rotates the active segment if it is older than MaxAge, then drains every
+// sealed segment in dir, retrying its records against inner and removing it once empty.
+func (t *spooledTarget) sweepOnce() {
+	t.mu.Lock()
+	activePath := t.path
+	if t.file != nil && t.size > 0 && time.Since(t.openedAt) >= t.opts.MaxAge {
+		if err := t.rotateLocked(); err == nil {
+			activePath = t.path
+		}
+	}
+	t.mu.Unlock()
+
+	sealed, err := filepath.Glob(filepath.Join(t.dir, "*.seg"))
+	if err != nil {
+		return
+	}
+	for _, path := range sealed {
+		if path == activePath {
+			continue
+		}
+		t.drainFile(path)
+	}
+}
+
+// drainFile replays every valid frame in path against inner, rewriting the file to keep only the
+// records that failed to deliver, or removing it entirely once every record has been delivered.
+func (t *spooledTarget) drainFile(path string) {
+	frames, err := readFrames(path)
+	if err != nil || len(frames) == 0 {
+		os.Remove(path)
+		return
+	}
+
+	remaining := make([][]byte, 0, len(frames))
+	for _, payload := range frames {
+		r, err := decodeSpoolRecord(payload)
+		if err != nil {
+			// Not a record we can make sense of; drop it rather than retry forever.
+			continue
+		}
+		if err := t.forward(context.Background(), []slog.Record{r}); err != nil {
+			remaining = append(remaining, payload)
+		}
+	}
+
+	if len(remaining) == 0 {
+		os.Remove(path)
+		return
+	}
+	rewriteSegment(path, remaining)
+}
+
+// A spoolRecord is the JSON representation of a slog.Record persisted to a segment.
+type spoolRecord struct {
+	Time    time.Time              `json:"time"`
+	Level   slog.Level             `json:"level"`
+	Message string                 `json:"message"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// encodeSpoolRecord converts r into the payload stored in a segment frame.
+func encodeSpoolRecord(r slog.Record) ([]byte, error) {
+	attrs := make(map[string]interface{}, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.Any()
+		return true
+	})
+	return json.Marshal(spoolRecord{Time: r.Time, Level: r.Level, Message: r.Message, Attrs: attrs})
+}
+
+// decodeSpoolRecord converts a segment frame's payload back into a slog.Record. The record has
+// no PC, since the original caller frame is not preserved across a restart.
+func decodeSpoolRecord(payload []byte) (slog.Record, error) {
+	var sr spoolRecord
+	if err := json.Unmarshal(payload, &sr); err != nil {
+		return slog.Record{}, err
+	}
+	r := slog.NewRecord(sr.Time, sr.Level, sr.Message, 0)
+	for k, v := range sr.Attrs {
+		r.AddAttrs(slog.Any(k, v))
+	}
+	return r, nil
+}
+
+// writeFrame appends a length-prefixed, checksummed frame to w: a 4-byte big-endian length, a
+// 4-byte big-endian CRC32 of payload, then payload itself.
+func writeFrame(w *os.File, payload []byte) error {
+	var header [frameHeaderSize]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(header[4:8], crc32.ChecksumIEEE(payload))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readFrames reads every valid frame from path in order, stopping at the first truncated or
+// checksum-mismatched frame (the tell-tale sign of a write interrupted by a crash).
+func readFrames(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var frames [][]byte
+	for len(data) >= frameHeaderSize {
+		length := binary.BigEndian.Uint32(data[0:4])
+		checksum := binary.BigEndian.Uint32(data[4:8])
+		data = data[frameHeaderSize:]
+		if uint32(len(data)) < length {
+			break
+		}
+		payload := data[:length]
+		if crc32.ChecksumIEEE(payload) != checksum {
+			break
+		}
+		frames = append(frames, payload)
+		data = data[length:]
+	}
+	return frames, nil
+}
+
+// rewriteSegment replaces path's contents with frames, or removes path if frames is empty.
+func rewriteSegment(path string, frames [][]byte) error {
+	if len(frames) == 0 {
+		return os.Remove(path)
+	}
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	for _, payload := range frames {
+		if err := writeFrame(f, payload); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+var _ LogTarget = (*spooledTarget)(nil)
+var _ BatchLogTarget = (*spooledTarget)(nil)
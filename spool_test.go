@@ -0,0 +1,184 @@
+package goLogger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyTarget is a BatchLogTarget whose first failures calls to SendLogs fail before it starts
+// succeeding, used to exercise a dispatcher retrying a spooled target.
+type flakyTarget struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	records  []slog.Record
+}
+
+func (f *flakyTarget) SendLog(ctx context.Context, r slog.Record) error {
+	return f.SendLogs(ctx, []slog.Record{r})
+}
+
+func (f *flakyTarget) SendLogs(_ context.Context, records []slog.Record) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts++
+	if f.attempts <= f.failures {
+		return errors.New("flaky target: simulated failure")
+	}
+	f.records = append(f.records, records...)
+	return nil
+}
+
+func (f *flakyTarget) attemptCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+func TestWriteReadFrames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.seg")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	payloads := [][]byte{[]byte("a"), []byte("bb"), []byte("ccc")}
+	for _, p := range payloads {
+		if err := writeFrame(f, p); err != nil {
+			t.Fatalf("writeFrame: %v", err)
+		}
+	}
+	f.Close()
+
+	got, err := readFrames(path)
+	if err != nil {
+		t.Fatalf("readFrames: %v", err)
+	}
+	if len(got) != len(payloads) {
+		t.Fatalf("expected %d frames, got %d", len(payloads), len(got))
+	}
+	for i := range payloads {
+		if string(got[i]) != string(payloads[i]) {
+			t.Fatalf("frame %d: got %q, want %q", i, got[i], payloads[i])
+		}
+	}
+}
+
+// TestReadFramesStopsAtCorruption mimics a write interrupted by a crash: a valid frame followed
+// by a header whose length doesn't match the bytes actually on disk.
+func TestReadFramesStopsAtCorruption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.seg")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if err := writeFrame(f, []byte("good")); err != nil {
+		t.Fatalf("writeFrame: %v", err)
+	}
+	if _, err := f.Write([]byte{0, 0, 0, 5, 1, 2, 3, 4}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	f.Close()
+
+	got, err := readFrames(path)
+	if err != nil {
+		t.Fatalf("readFrames: %v", err)
+	}
+	if len(got) != 1 || string(got[0]) != "good" {
+		t.Fatalf("expected to stop after the first valid frame, got %v", got)
+	}
+}
+
+// TestSpooledTargetForwardsBatchInOneCall is a regression test: wrapping a BatchLogTarget in a
+// spool must still deliver a batch as a single SendLogs call, not one call per record.
+func TestSpooledTargetForwardsBatchInOneCall(t *testing.T) {
+	inner := &fakeBatchTarget{}
+	target, err := NewSpooledTarget(inner, t.TempDir(), SpoolOptions{})
+	if err != nil {
+		t.Fatalf("NewSpooledTarget: %v", err)
+	}
+	batchTarget, ok := target.(BatchLogTarget)
+	if !ok {
+		t.Fatalf("expected the spooled target to implement BatchLogTarget")
+	}
+
+	records := []slog.Record{
+		slog.NewRecord(time.Now(), slog.LevelInfo, "one", 0),
+		slog.NewRecord(time.Now(), slog.LevelInfo, "two", 0),
+		slog.NewRecord(time.Now(), slog.LevelInfo, "three", 0),
+	}
+	if err := batchTarget.SendLogs(context.Background(), records); err != nil {
+		t.Fatalf("SendLogs: %v", err)
+	}
+
+	if got := inner.len(); got != len(records) {
+		t.Fatalf("expected %d records delivered, got %d", len(records), got)
+	}
+	if inner.batchCalls != 1 {
+		t.Fatalf("expected the batch to be delivered in a single SendLogs call, got %d calls", inner.batchCalls)
+	}
+}
+
+// TestSpooledTargetDoesNotDuplicateFramesOnDispatcherRetry is a regression test: a spooled
+// target registered directly with a dispatcher must persist a logical send exactly once even
+// when the inner target fails and the dispatcher would otherwise retry the same batch.
+func TestSpooledTargetDoesNotDuplicateFramesOnDispatcherRetry(t *testing.T) {
+	dir := t.TempDir()
+	inner := &flakyTarget{failures: 2}
+	spooled, err := NewSpooledTarget(inner, dir, SpoolOptions{})
+	if err != nil {
+		t.Fatalf("NewSpooledTarget: %v", err)
+	}
+
+	d := newTargetDispatcher(
+		Target{LogTarget: spooled},
+		Options{
+			QueueCapacity: 10,
+			Workers:       1,
+			MaxBatch:      10,
+			FlushInterval: 10 * time.Millisecond,
+			Retry: RetryOptions{
+				MaxRetries:       3,
+				BaseDelay:        time.Millisecond,
+				MaxDelay:         time.Millisecond,
+				FailureThreshold: 1,
+				WindowSize:       20,
+				CoolDown:         time.Second,
+			},
+		},
+		make(chan error, 10),
+	)
+
+	d.enqueue(slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := d.shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if got := inner.attemptCount(); got != 1 {
+		t.Fatalf("expected the dispatcher to make exactly one delivery attempt (retries are the sweep's job), got %d", got)
+	}
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	var frameCount int
+	for _, seg := range segments {
+		frames, err := readFrames(seg)
+		if err != nil {
+			t.Fatalf("readFrames(%s): %v", seg, err)
+		}
+		frameCount += len(frames)
+	}
+	if frameCount != 1 {
+		t.Fatalf("expected exactly 1 persisted frame for 1 logical send, got %d", frameCount)
+	}
+}
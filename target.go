@@ -0,0 +1,53 @@
+package goLogger
+
+import "log/slog"
+
+// TargetOptions controls which log records a target receives.
+// A zero-value TargetOptions's MinLevel is slog.LevelInfo, so Debug records are rejected by
+// default; set MinLevel to slog.LevelDebug (or lower) for the filter to accept every level. Empty
+// AllowKinds/DenyKinds/Predicate apply no additional filtering. The handler New builds is itself
+// enabled only for slog.LevelInfo and above, so records below Info never reach a target through a
+// Logger from New, whatever MinLevel is.
+type TargetOptions struct {
+	// MinLevel is the lowest level a record must have to reach the target.
+	MinLevel slog.Level
+	// AllowKinds, if non-empty, restricts the target to records tagged with one of these kinds
+	// via WithKind. Records without a kind are rejected when AllowKinds is set.
+	AllowKinds []string
+	// DenyKinds rejects records tagged with one of these kinds via WithKind.
+	DenyKinds []string
+	// Predicate, if set, is evaluated after the level and kind checks and must return true
+	// for the record to reach the target.
+	Predicate func(r slog.Record) bool
+}
+
+// A Target pairs a LogTarget with the TargetOptions that decide which records reach it.
+type Target struct {
+	LogTarget
+	Options TargetOptions
+}
+
+// NewTarget pairs target with opts for registration with New.
+func NewTarget(target LogTarget, opts TargetOptions) Target {
+	return Target{LogTarget: target, Options: opts}
+}
+
+// accepts reports whether r passes this target's level, kind, and predicate filters.
+func (t Target) accepts(r slog.Record) bool {
+	if r.Level < t.Options.MinLevel {
+		return false
+	}
+	kind, hasKind := kindFromRecord(r)
+	if len(t.Options.AllowKinds) > 0 {
+		if !hasKind || !containsKind(t.Options.AllowKinds, kind) {
+			return false
+		}
+	}
+	if len(t.Options.DenyKinds) > 0 && hasKind && containsKind(t.Options.DenyKinds, kind) {
+		return false
+	}
+	if t.Options.Predicate != nil && !t.Options.Predicate(r) {
+		return false
+	}
+	return true
+}
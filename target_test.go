@@ -0,0 +1,102 @@
+package goLogger
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func recordWithKind(level slog.Level, kind string) slog.Record {
+	r := slog.NewRecord(time.Now(), level, "msg", 0)
+	if kind != "" {
+		r.AddAttrs(slog.String(kindAttrKey, kind))
+	}
+	return r
+}
+
+func TestTargetAcceptsMinLevel(t *testing.T) {
+	target := Target{Options: TargetOptions{MinLevel: slog.LevelWarn}}
+
+	if target.accepts(recordWithKind(slog.LevelInfo, "")) {
+		t.Fatalf("expected a record below MinLevel to be rejected")
+	}
+	if !target.accepts(recordWithKind(slog.LevelError, "")) {
+		t.Fatalf("expected a record at or above MinLevel to be accepted")
+	}
+}
+
+func TestTargetAcceptsAllowKinds(t *testing.T) {
+	target := Target{Options: TargetOptions{AllowKinds: []string{"audit"}}}
+
+	if target.accepts(recordWithKind(slog.LevelInfo, "")) {
+		t.Fatalf("expected a record with no kind to be rejected when AllowKinds is set")
+	}
+	if target.accepts(recordWithKind(slog.LevelInfo, "request")) {
+		t.Fatalf("expected a record with a kind not in AllowKinds to be rejected")
+	}
+	if !target.accepts(recordWithKind(slog.LevelInfo, "audit")) {
+		t.Fatalf("expected a record with an allowed kind to be accepted")
+	}
+}
+
+func TestTargetAcceptsDenyKinds(t *testing.T) {
+	target := Target{Options: TargetOptions{DenyKinds: []string{"debug-trace"}}}
+
+	if target.accepts(recordWithKind(slog.LevelInfo, "debug-trace")) {
+		t.Fatalf("expected a record with a denied kind to be rejected")
+	}
+	if !target.accepts(recordWithKind(slog.LevelInfo, "request")) {
+		t.Fatalf("expected a record with a non-denied kind to be accepted")
+	}
+	if !target.accepts(recordWithKind(slog.LevelInfo, "")) {
+		t.Fatalf("expected a record with no kind to be accepted when DenyKinds doesn't match")
+	}
+}
+
+func TestTargetAcceptsPredicate(t *testing.T) {
+	target := Target{Options: TargetOptions{
+		Predicate: func(r slog.Record) bool {
+			matched := false
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == "service" && a.Value.String() == "payments" {
+					matched = true
+					return false
+				}
+				return true
+			})
+			return matched
+		},
+	}}
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("service", "payments"))
+	if !target.accepts(r) {
+		t.Fatalf("expected the predicate to accept a matching record")
+	}
+
+	r2 := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r2.AddAttrs(slog.String("service", "billing"))
+	if target.accepts(r2) {
+		t.Fatalf("expected the predicate to reject a non-matching record")
+	}
+}
+
+func TestTargetAcceptsCombinesAllFilters(t *testing.T) {
+	target := Target{Options: TargetOptions{
+		MinLevel:   slog.LevelWarn,
+		AllowKinds: []string{"audit"},
+		Predicate:  func(r slog.Record) bool { return r.Message == "expected" },
+	}}
+
+	passing := recordWithKind(slog.LevelError, "audit")
+	passing.Message = "expected"
+	if !target.accepts(passing) {
+		t.Fatalf("expected a record satisfying every filter to be accepted")
+	}
+
+	failingPredicate := recordWithKind(slog.LevelError, "audit")
+	failingPredicate.Message = "unexpected"
+	if target.accepts(failingPredicate) {
+		t.Fatalf("expected a record failing the predicate to be rejected even if level and kind pass")
+	}
+}
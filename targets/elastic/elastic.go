@@ -0,0 +1,95 @@
+// Package elastic provides a LogTarget that indexes log records into Elasticsearch via its Bulk API.
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	goLogger "github.com/richi0/goLogger"
+)
+
+// A Target indexes log records into an Elasticsearch index using the _bulk endpoint.
+type Target struct {
+	bulkEndpoint string
+	index        string
+	apiKey       string
+	client       *http.Client
+}
+
+// New creates a Target that bulk-indexes into index at esURL (e.g. "http://localhost:9200").
+// apiKey, if non-empty, is sent as an "Authorization: ApiKey <apiKey>" header.
+func New(esURL, index, apiKey string) *Target {
+	return &Target{
+		bulkEndpoint: esURL + "/_bulk",
+		index:        index,
+		apiKey:       apiKey,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendLog indexes a single log record.
+func (t *Target) SendLog(ctx context.Context, r slog.Record) error {
+	return t.SendLogs(ctx, []slog.Record{r})
+}
+
+// SendLogs indexes records in a single bulk request.
+func (t *Target) SendLogs(ctx context.Context, records []slog.Record) error {
+	var body bytes.Buffer
+	for _, r := range records {
+		action, err := json.Marshal(map[string]interface{}{
+			"index": map[string]string{"_index": t.index},
+		})
+		if err != nil {
+			return err
+		}
+		doc, err := json.Marshal(fields(r))
+		if err != nil {
+			return err
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(doc)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.bulkEndpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+t.apiKey)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("elastic target: unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// fields flattens a slog.Record into the JSON document stored in Elasticsearch.
+func fields(r slog.Record) map[string]interface{} {
+	f := make(map[string]interface{}, r.NumAttrs()+3)
+	f["@timestamp"] = r.Time
+	f["level"] = r.Level.String()
+	f["message"] = r.Message
+	r.Attrs(func(a slog.Attr) bool {
+		f[a.Key] = a.Value.Any()
+		return true
+	})
+	return f
+}
+
+var (
+	_ goLogger.LogTarget      = (*Target)(nil)
+	_ goLogger.BatchLogTarget = (*Target)(nil)
+)
@@ -0,0 +1,134 @@
+// Package gcloud provides a LogTarget that writes log records to Google Cloud Logging.
+package gcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"time"
+
+	goLogger "github.com/richi0/goLogger"
+)
+
+const writeEndpoint = "https://logging.googleapis.com/v2/entries:write"
+
+// A Target writes log records to a Cloud Logging log via the entries:write REST API.
+type Target struct {
+	projectID string
+	logID     string
+	token     string
+	client    *http.Client
+}
+
+// New creates a Target that writes to projects/<projectID>/logs/<logID>. token is an OAuth2
+// access token, sent as an "Authorization: Bearer <token>" header; refreshing it, if needed, is
+// the caller's responsibility.
+func New(projectID, logID, token string) *Target {
+	return &Target{
+		projectID: projectID,
+		logID:     logID,
+		token:     token,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// entriesWriteRequest is the entries:write request body.
+type entriesWriteRequest struct {
+	LogName  string                 `json:"logName"`
+	Resource map[string]interface{} `json:"resource"`
+	Entries  []logEntry             `json:"entries"`
+}
+
+// logEntry mirrors the fields of Cloud Logging's LogEntry that goLogger can populate.
+type logEntry struct {
+	Severity       string                 `json:"severity"`
+	Timestamp      string                 `json:"timestamp"`
+	JSONPayload    map[string]interface{} `json:"jsonPayload"`
+	SourceLocation map[string]interface{} `json:"sourceLocation,omitempty"`
+}
+
+// SendLog writes a single log record.
+func (t *Target) SendLog(ctx context.Context, r slog.Record) error {
+	return t.SendLogs(ctx, []slog.Record{r})
+}
+
+// SendLogs writes records in a single entries:write request.
+func (t *Target) SendLogs(ctx context.Context, records []slog.Record) error {
+	entries := make([]logEntry, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, toLogEntry(r))
+	}
+	reqBody := entriesWriteRequest{
+		LogName:  fmt.Sprintf("projects/%s/logs/%s", t.projectID, t.logID),
+		Resource: map[string]interface{}{"type": "global"},
+		Entries:  entries,
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, writeEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gcloud target: unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// toLogEntry maps a slog.Record onto a Cloud Logging LogEntry.
+func toLogEntry(r slog.Record) logEntry {
+	payload := make(map[string]interface{}, r.NumAttrs()+1)
+	payload["message"] = r.Message
+	r.Attrs(func(a slog.Attr) bool {
+		payload[a.Key] = a.Value.Any()
+		return true
+	})
+
+	entry := logEntry{
+		Severity:    severity(r.Level),
+		Timestamp:   r.Time.Format("2006-01-02T15:04:05.000000000Z07:00"),
+		JSONPayload: payload,
+	}
+	if r.PC != 0 {
+		fs := runtime.CallersFrames([]uintptr{r.PC})
+		f, _ := fs.Next()
+		entry.SourceLocation = map[string]interface{}{
+			"file":     f.File,
+			"line":     fmt.Sprintf("%d", f.Line),
+			"function": f.Function,
+		}
+	}
+	return entry
+}
+
+// severity maps a slog.Level onto a Cloud Logging severity string.
+func severity(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+var (
+	_ goLogger.LogTarget      = (*Target)(nil)
+	_ goLogger.BatchLogTarget = (*Target)(nil)
+)
@@ -0,0 +1,84 @@
+// Package http provides a generic LogTarget that POSTs log records as JSON to any HTTP endpoint.
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	goLogger "github.com/richi0/goLogger"
+)
+
+// A Target POSTs JSON-encoded log records to an HTTP endpoint. It is meant for wiring up
+// arbitrary HTTP log APIs without writing a dedicated LogTarget.
+type Target struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// New creates a Target that POSTs to endpoint. headers is sent with every request, which is
+// where callers set auth headers such as "Authorization".
+func New(endpoint string, headers map[string]string) *Target {
+	return &Target{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendLog sends a single log record.
+func (t *Target) SendLog(ctx context.Context, r slog.Record) error {
+	return t.SendLogs(ctx, []slog.Record{r})
+}
+
+// SendLogs POSTs records as a single JSON array.
+func (t *Target) SendLogs(ctx context.Context, records []slog.Record) error {
+	payload := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		payload = append(payload, fields(r))
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http target: unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// fields flattens a slog.Record into a JSON-friendly map.
+func fields(r slog.Record) map[string]interface{} {
+	f := make(map[string]interface{}, r.NumAttrs()+3)
+	f["time"] = r.Time
+	f["level"] = r.Level.String()
+	f["message"] = r.Message
+	r.Attrs(func(a slog.Attr) bool {
+		f[a.Key] = a.Value.Any()
+		return true
+	})
+	return f
+}
+
+var (
+	_ goLogger.LogTarget      = (*Target)(nil)
+	_ goLogger.BatchLogTarget = (*Target)(nil)
+)
@@ -0,0 +1,95 @@
+// Package loki provides a LogTarget that pushes log records to Grafana Loki.
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	goLogger "github.com/richi0/goLogger"
+)
+
+// A Target pushes log records to Loki's HTTP push API as a single stream identified by labels.
+type Target struct {
+	pushEndpoint string
+	labels       map[string]string
+	client       *http.Client
+}
+
+// New creates a Target that pushes to pushEndpoint (e.g. "http://loki:3100/loki/api/v1/push")
+// with the given stream labels attached to every entry.
+func New(pushEndpoint string, labels map[string]string) *Target {
+	return &Target{
+		pushEndpoint: pushEndpoint,
+		labels:       labels,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// pushRequest is Loki's push API request body.
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+// stream is a single Loki stream: a label set and its [timestamp, line] values.
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// SendLog sends a single log record.
+func (t *Target) SendLog(ctx context.Context, r slog.Record) error {
+	return t.SendLogs(ctx, []slog.Record{r})
+}
+
+// SendLogs pushes records as a single stream entry batch.
+func (t *Target) SendLogs(ctx context.Context, records []slog.Record) error {
+	values := make([][2]string, 0, len(records))
+	for _, r := range records {
+		line, err := json.Marshal(lineFields(r))
+		if err != nil {
+			return err
+		}
+		values = append(values, [2]string{strconv.FormatInt(r.Time.UnixNano(), 10), string(line)})
+	}
+	body, err := json.Marshal(pushRequest{Streams: []stream{{Stream: t.labels, Values: values}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.pushEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("loki target: unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// lineFields flattens a slog.Record into the JSON log line stored in a stream value.
+func lineFields(r slog.Record) map[string]interface{} {
+	f := make(map[string]interface{}, r.NumAttrs()+2)
+	f["level"] = r.Level.String()
+	f["message"] = r.Message
+	r.Attrs(func(a slog.Attr) bool {
+		f[a.Key] = a.Value.Any()
+		return true
+	})
+	return f
+}
+
+var (
+	_ goLogger.LogTarget      = (*Target)(nil)
+	_ goLogger.BatchLogTarget = (*Target)(nil)
+)
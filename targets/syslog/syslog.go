@@ -0,0 +1,131 @@
+// Package syslog provides a LogTarget that writes RFC 5424 syslog messages over UDP, TCP, or TLS.
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	goLogger "github.com/richi0/goLogger"
+)
+
+// A Network is a transport a Target can dial.
+type Network string
+
+const (
+	NetworkUDP Network = "udp"
+	NetworkTCP Network = "tcp"
+	NetworkTLS Network = "tls"
+)
+
+// facilityLocal0 is the syslog facility goLogger messages are tagged with.
+const facilityLocal0 = 16
+
+// A Target writes RFC 5424 syslog messages to a syslog server. It dials lazily and redials
+// on the next send after a write failure.
+type Target struct {
+	network   Network
+	addr      string
+	appName   string
+	hostname  string
+	tlsConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// New creates a Target that sends messages tagged with appName to addr over network.
+// tlsConfig is only used when network is NetworkTLS.
+func New(network Network, addr, appName string, tlsConfig *tls.Config) *Target {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &Target{
+		network:   network,
+		addr:      addr,
+		appName:   appName,
+		hostname:  hostname,
+		tlsConfig: tlsConfig,
+	}
+}
+
+// SendLog writes a single RFC 5424 message for r. The dial and the write share t.mu so
+// concurrent callers (the dispatcher may run several workers per target) can never interleave
+// writes on the same connection.
+func (t *Target) SendLog(ctx context.Context, r slog.Record) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	conn, err := t.connectionLocked()
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte(t.format(r))); err != nil {
+		t.conn = nil
+		return err
+	}
+	return nil
+}
+
+// connectionLocked returns the current connection, dialing a new one if none is open.
+// t.mu must be held by the caller.
+func (t *Target) connectionLocked() (net.Conn, error) {
+	if t.conn != nil {
+		return t.conn, nil
+	}
+	conn, err := t.dial()
+	if err != nil {
+		return nil, err
+	}
+	t.conn = conn
+	return conn, nil
+}
+
+// dial opens a new connection for t.network.
+func (t *Target) dial() (net.Conn, error) {
+	switch t.network {
+	case NetworkTLS:
+		return tls.Dial("tcp", t.addr, t.tlsConfig)
+	case NetworkTCP:
+		return net.Dial("tcp", t.addr)
+	case NetworkUDP:
+		return net.Dial("udp", t.addr)
+	default:
+		return nil, fmt.Errorf("syslog target: unknown network %q", t.network)
+	}
+}
+
+// format renders r as an RFC 5424 message: "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG".
+func (t *Target) format(r slog.Record) string {
+	pri := facilityLocal0*8 + severity(r.Level)
+	return fmt.Sprintf("<%d>1 %s %s %s %d - - %s\n",
+		pri,
+		r.Time.Format(time.RFC3339Nano),
+		t.hostname,
+		t.appName,
+		os.Getpid(),
+		r.Message,
+	)
+}
+
+// severity maps a slog.Level onto an RFC 5424 severity (0-7, lower is more severe).
+func severity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 3 // error
+	case level >= slog.LevelWarn:
+		return 4 // warning
+	case level >= slog.LevelInfo:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
+
+var _ goLogger.LogTarget = (*Target)(nil)
@@ -0,0 +1,202 @@
+package goLogger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"log/slog"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// HandlerFormat selects the slog.Handler New builds for the base writer.
+type HandlerFormat int
+
+const (
+	// FormatJSON writes logs as JSON, one object per line.
+	FormatJSON HandlerFormat = iota
+	// FormatTerminal writes aligned, human-readable lines, colored when the writer is a TTY.
+	FormatTerminal
+)
+
+// levelColors holds the ANSI color escape per slog.Level, used by terminalHandler when its
+// writer is a TTY.
+var levelColors = map[slog.Level]string{
+	slog.LevelDebug: "\x1b[90m",
+	slog.LevelInfo:  "\x1b[36m",
+	slog.LevelWarn:  "\x1b[33m",
+	slog.LevelError: "\x1b[31m",
+}
+
+const colorReset = "\x1b[0m"
+
+// A StackTracer can be implemented by error values to expose a multi-frame stack trace.
+// It matches the convention used by github.com/pkg/errors, so errors created with
+// errors.New or errors.Wrap render their stack when logged through the terminal handler.
+type StackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// A terminalHandler is a slog.Handler that renders aligned key=value lines for interactive use,
+// inspired by log15 and zerolog's console writers.
+type terminalHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	color  bool
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewTerminalHandler creates a slog.Handler with aligned key=value output, ANSI color per level
+// when w is a TTY (detected via golang.org/x/term), an abbreviated caller (pkg/file.go:42), and
+// %+v stack traces for error attrs that implement StackTracer.
+func NewTerminalHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	var level slog.Leveler = slog.LevelInfo
+	if opts != nil && opts.Level != nil {
+		level = opts.Level
+	}
+	return &terminalHandler{
+		mu:    &sync.Mutex{},
+		w:     w,
+		color: isTerminal(w),
+		level: level,
+	}
+}
+
+// isTerminal reports whether w is a TTY.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// Enabled returns true if the provided log level is enabled.
+func (h *terminalHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes r as a single aligned, optionally colored line.
+func (h *terminalHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(r.Time.Format("2006-01-02T15:04:05.000"))
+	b.WriteByte(' ')
+
+	levelStr := fmt.Sprintf("%-5s", r.Level.String())
+	if h.color {
+		b.WriteString(levelColors[r.Level])
+		b.WriteString(levelStr)
+		b.WriteString(colorReset)
+	} else {
+		b.WriteString(levelStr)
+	}
+	b.WriteByte(' ')
+
+	if r.PC != 0 {
+		b.WriteString(abbreviateCaller(r.PC))
+		b.WriteByte(' ')
+	}
+	b.WriteString(r.Message)
+
+	prefix := groupPrefix(h.groups)
+	attrs := make([]slog.Attr, 0, len(h.attrs)+r.NumAttrs())
+	attrs = append(attrs, h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		if prefix != "" {
+			a.Key = prefix + a.Key
+		}
+		attrs = append(attrs, a)
+		return true
+	})
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	for _, a := range attrs {
+		b.WriteByte(' ')
+		b.WriteString(a.Key)
+		b.WriteByte('=')
+		b.WriteString(formatAttrValue(a.Value))
+	}
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// formatAttrValue renders v, expanding error values that implement StackTracer into their
+// message plus a %+v-formatted stack trace.
+func formatAttrValue(v slog.Value) string {
+	if err, ok := v.Any().(error); ok {
+		if st, ok := err.(StackTracer); ok {
+			return fmt.Sprintf("%q\n%+v", err.Error(), st.StackTrace())
+		}
+		return quoteIfNeeded(err.Error())
+	}
+	return quoteIfNeeded(v.String())
+}
+
+// quoteIfNeeded quotes s if it contains whitespace or a quote, so key=value output stays parseable.
+func quoteIfNeeded(s string) string {
+	if strings.ContainsAny(s, " \t\n\"") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// abbreviateCaller renders a caller PC as "pkg/file.go:line".
+func abbreviateCaller(pc uintptr) string {
+	fs := runtime.CallersFrames([]uintptr{pc})
+	f, _ := fs.Next()
+	file := f.File
+	if idx := strings.LastIndex(file, "/"); idx >= 0 {
+		if idx2 := strings.LastIndex(file[:idx], "/"); idx2 >= 0 {
+			file = file[idx2+1:]
+		}
+	}
+	return fmt.Sprintf("%s:%d", file, f.Line)
+}
+
+// WithAttrs returns a new terminalHandler with the provided attributes, prefixed with the
+// current group path so they render the same nesting a JSON handler would show as a map.
+func (h *terminalHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := groupPrefix(h.groups)
+	prefixed := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		if prefix != "" {
+			a.Key = prefix + a.Key
+		}
+		prefixed[i] = a
+	}
+	newAttrs := make([]slog.Attr, 0, len(h.attrs)+len(prefixed))
+	newAttrs = append(newAttrs, h.attrs...)
+	newAttrs = append(newAttrs, prefixed...)
+	return &terminalHandler{mu: h.mu, w: h.w, color: h.color, level: h.level, attrs: newAttrs, groups: h.groups}
+}
+
+// WithGroup returns a new terminalHandler with the provided group name. Attrs added after this
+// call, whether via WithAttrs or directly on a record, have their keys prefixed with the full
+// group path (e.g. "req.id") since there is no nested-map rendering to fall back on.
+func (h *terminalHandler) WithGroup(name string) slog.Handler {
+	newGroups := make([]string, 0, len(h.groups)+1)
+	newGroups = append(newGroups, h.groups...)
+	newGroups = append(newGroups, name)
+	return &terminalHandler{mu: h.mu, w: h.w, color: h.color, level: h.level, attrs: h.attrs, groups: newGroups}
+}
+
+// groupPrefix joins groups into the dot-separated prefix applied to subsequent attr keys, with a
+// trailing dot, or "" if groups is empty.
+func groupPrefix(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return strings.Join(groups, ".") + "."
+}
@@ -0,0 +1,87 @@
+package goLogger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestIsTerminalFalseForNonFileWriter(t *testing.T) {
+	if isTerminal(&bytes.Buffer{}) {
+		t.Fatalf("expected a non-*os.File writer to never be detected as a TTY")
+	}
+}
+
+func TestTerminalHandlerNoColorForNonTTY(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewTerminalHandler(&buf, nil)
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, "boom", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("expected no ANSI color codes when the writer isn't a TTY, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "boom") {
+		t.Fatalf("expected the message in the output, got %q", buf.String())
+	}
+}
+
+func TestTerminalHandlerGroupPrefixesAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewTerminalHandler(&buf, nil).WithGroup("req").WithAttrs([]slog.Attr{slog.Int("id", 42)})
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	r.AddAttrs(slog.String("extra", "x"))
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "req.id=42") {
+		t.Fatalf("expected attrs set via WithAttrs after WithGroup to be prefixed with the group path, got %q", out)
+	}
+	if !strings.Contains(out, "req.extra=x") {
+		t.Fatalf("expected record attrs to also be prefixed with the active group path, got %q", out)
+	}
+}
+
+func TestFormatAttrValueRendersStackTrace(t *testing.T) {
+	err := errors.New("boom")
+	got := formatAttrValue(slog.AnyValue(err))
+	if !strings.Contains(got, "boom") {
+		t.Fatalf("expected the error message in the rendered value, got %q", got)
+	}
+	if !strings.Contains(got, "terminal_test.go") {
+		t.Fatalf("expected a stack frame from this test file in the rendered value, got %q", got)
+	}
+}
+
+func TestFormatAttrValuePlainError(t *testing.T) {
+	got := formatAttrValue(slog.AnyValue(errNoStack{}))
+	if got != `"plain error"` {
+		t.Fatalf("expected a quoted plain error message, got %q", got)
+	}
+}
+
+type errNoStack struct{}
+
+func (errNoStack) Error() string { return "plain error" }
+
+func TestAbbreviateCaller(t *testing.T) {
+	_, _, _, _ = runtime.Caller(0)
+	pcs := make([]uintptr, 1)
+	runtime.Callers(1, pcs)
+	got := abbreviateCaller(pcs[0])
+	if !strings.Contains(got, "terminal_test.go:") {
+		t.Fatalf("expected an abbreviated pkg/file.go:line caller, got %q", got)
+	}
+}